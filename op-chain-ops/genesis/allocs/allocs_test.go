@@ -0,0 +1,57 @@
+package allocs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestForgeAllocsJSONRoundTrip(t *testing.T) {
+	addr := common.HexToAddress("0x1234")
+	in := &ForgeAllocs{
+		Accounts: types.GenesisAlloc{
+			addr: {Balance: big.NewInt(1), Nonce: 2, Code: []byte{0x60, 0x00}},
+		},
+	}
+
+	b, err := in.MarshalJSON()
+	require.NoError(t, err)
+
+	var out ForgeAllocs
+	require.NoError(t, out.UnmarshalJSON(b))
+	require.Equal(t, in.Accounts[addr].Balance, out.Accounts[addr].Balance)
+	require.Equal(t, in.Accounts[addr].Code, out.Accounts[addr].Code)
+}
+
+func TestForgeAllocsValidate(t *testing.T) {
+	require.Error(t, (&ForgeAllocs{}).Validate())
+
+	addr := common.HexToAddress("0x1234")
+	ok := &ForgeAllocs{Accounts: types.GenesisAlloc{addr: {Balance: big.NewInt(1)}}}
+	require.NoError(t, ok.Validate())
+}
+
+func TestForgeAllocsDiff(t *testing.T) {
+	same := common.HexToAddress("0x01")
+	changed := common.HexToAddress("0x02")
+	removed := common.HexToAddress("0x03")
+	added := common.HexToAddress("0x04")
+
+	a := &ForgeAllocs{Accounts: types.GenesisAlloc{
+		same:    {Balance: big.NewInt(1)},
+		changed: {Balance: big.NewInt(1)},
+		removed: {Balance: big.NewInt(1)},
+	}}
+	b := &ForgeAllocs{Accounts: types.GenesisAlloc{
+		same:    {Balance: big.NewInt(1)},
+		changed: {Balance: big.NewInt(2)},
+		added:   {Balance: big.NewInt(1)},
+	}}
+
+	diff := a.Diff(b)
+	require.Len(t, diff, 3)
+}
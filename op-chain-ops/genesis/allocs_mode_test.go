@@ -0,0 +1,34 @@
+package genesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSelectAllocsMode exercises RegisterAllocsMode/SelectAllocsMode in
+// isolation: latest-match-wins ordering and the no-match error case. It
+// swaps out the package registry for the duration of the test rather than
+// relying on the real Delta/Ecotone registrations.
+func TestSelectAllocsMode(t *testing.T) {
+	saved := allocsModeRegistry
+	t.Cleanup(func() { allocsModeRegistry = saved })
+
+	offset := uint64(100)
+
+	allocsModeRegistry = nil
+	RegisterAllocsMode("base", func(cfg *DeployConfig) bool { return true }, "-base")
+	RegisterAllocsMode("later", func(cfg *DeployConfig) bool { return cfg.L2GenesisDeltaTimeOffset != nil }, "-later")
+
+	mode, err := SelectAllocsMode(&DeployConfig{})
+	require.NoError(t, err)
+	require.Equal(t, L2AllocsMode("base"), mode, "only the always-true predicate matches")
+
+	mode, err = SelectAllocsMode(&DeployConfig{L2GenesisDeltaTimeOffset: &offset})
+	require.NoError(t, err)
+	require.Equal(t, L2AllocsMode("later"), mode, "later registration wins when both predicates match")
+
+	allocsModeRegistry = nil
+	_, err = SelectAllocsMode(&DeployConfig{})
+	require.Error(t, err, "no registered mode should never silently resolve")
+}
@@ -0,0 +1,78 @@
+// Package genesistest provides a reusable ethclient/simulated.Backend
+// harness for building and driving an L2 genesis in tests.
+package genesistest
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// TestKey is the account every harness backend funds with test ETH, so
+// callers can submit transactions without managing their own keys.
+var TestKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+// Harness bundles a running simulated.Backend for a generated L2 genesis
+// together with bound predeploy callers, so callers don't need to
+// re-derive the funded accounts and bindings needed to drive it.
+type Harness struct {
+	Genesis *core.Genesis
+	Backend *simulated.Backend
+	Client  simulated.Client
+
+	L1Block *bindings.L1BlockCaller
+}
+
+// NewL2GenesisHarness builds an L2 genesis from cfg/allocs and boots it on a
+// simulated.Backend. The returned Harness owns the backend; callers must
+// call Close when done with it.
+func NewL2GenesisHarness(cfg *genesis.DeployConfig, allocs *genesis.ForgeAllocs) (*Harness, error) {
+	gen, err := genesis.BuildL2GenesisFromAllocs(cfg, allocs, emptyL1StartBlock())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build L2 genesis: %w", err)
+	}
+	gen.Alloc[crypto.PubkeyToAddress(TestKey.PublicKey)] = types.Account{
+		Balance: big.NewInt(10000000000000000),
+	}
+
+	backend := simulated.NewBackend(gen.Alloc, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = gen
+		ethConf.Preimages = true
+	})
+	client := backend.Client()
+
+	l1BlockCaller, err := bindings.NewL1BlockCaller(predeploys.L1BlockAddr, client)
+	if err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("failed to bind L1Block: %w", err)
+	}
+
+	return &Harness{
+		Genesis: gen,
+		Backend: backend,
+		Client:  client,
+		L1Block: l1BlockCaller,
+	}, nil
+}
+
+// Close tears down the underlying backend.
+func (h *Harness) Close() error {
+	return h.Backend.Close()
+}
+
+// emptyL1StartBlock returns a stand-in for the L1 block the L2 genesis was
+// generated against. Only its number and timestamp are observed by
+// BuildL2GenesisFromAllocs, so a bare block at height 0 is sufficient for tests.
+func emptyL1StartBlock() *types.Block {
+	return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+}
@@ -1,9 +1,7 @@
 package genesis
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/holiman/uint256"
@@ -12,8 +10,19 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis/allocs"
 )
 
+// ForgeAllocs is an alias of allocs.ForgeAllocs, kept so existing callers of
+// this package don't need to change their imports. New code that only needs
+// to read/validate a forge allocs dump should depend on the leaf
+// op-chain-ops/genesis/allocs package directly instead.
+type ForgeAllocs = allocs.ForgeAllocs
+
+// L2AllocsMode identifies a set of pre-built L2 predeploy allocs, generated
+// for a particular combination of activated hardforks. It is an opaque key
+// into the registry populated by RegisterAllocsMode; prefer SelectAllocsMode
+// over hardcoding one of the well-known modes below.
 type L2AllocsMode string
 
 const (
@@ -21,34 +30,136 @@ const (
 	L2AllocsEcotone L2AllocsMode = "" // the default in solidity scripting / testing
 )
 
+// allocsModeEntry is a single registered L2AllocsMode: the predicate that
+// decides whether a DeployConfig has activated it, and the filename suffix
+// of the allocs file backing it (allocs-l2{suffix}.json).
+type allocsModeEntry struct {
+	mode       L2AllocsMode
+	activation func(*DeployConfig) bool
+	suffix     string
+}
+
+// allocsModeRegistry holds every registered mode in registration order.
+// Modes are registered oldest-hardfork-first, since SelectAllocsMode returns
+// the last entry whose activation predicate matches.
+var allocsModeRegistry []allocsModeEntry
+
+// RegisterAllocsMode registers mode as active for a DeployConfig whenever
+// activation returns true, backed by the allocs file allocs-l2{suffix}.json.
+// It returns mode unchanged, so it can be used directly in a package-level
+// var declaration.
+func RegisterAllocsMode(mode L2AllocsMode, activation func(*DeployConfig) bool, suffix string) L2AllocsMode {
+	allocsModeRegistry = append(allocsModeRegistry, allocsModeEntry{mode: mode, activation: activation, suffix: suffix})
+	return mode
+}
+
+// SelectAllocsMode returns the latest registered L2AllocsMode whose
+// activation predicate is satisfied by cfg's fork times. It is an error for
+// no registered mode to match; callers must not silently fall back to the
+// newest predeploy bytecode for a chain that hasn't activated any of the
+// registered forks.
+func SelectAllocsMode(cfg *DeployConfig) (L2AllocsMode, error) {
+	var mode L2AllocsMode
+	matched := false
+	for _, entry := range allocsModeRegistry {
+		if entry.activation(cfg) {
+			mode = entry.mode
+			matched = true
+		}
+	}
+	if !matched {
+		return "", fmt.Errorf("no registered L2AllocsMode activation matched the deploy config's fork times")
+	}
+	return mode, nil
+}
+
+func init() {
+	RegisterAllocsMode(L2AllocsDelta, func(cfg *DeployConfig) bool {
+		return cfg.L2GenesisDeltaTimeOffset != nil && cfg.L2GenesisEcotoneTimeOffset == nil
+	}, "-delta")
+	RegisterAllocsMode(L2AllocsEcotone, func(cfg *DeployConfig) bool {
+		return cfg.L2GenesisEcotoneTimeOffset != nil
+	}, "")
+}
+
+// permit2ChainIDOffsets records the byte offset of the permit2 contract's
+// chain-ID immutable, per L2AllocsMode. The offset can shift between the
+// compiler versions used to regenerate allocs for each hardfork, so it is
+// tracked per-mode rather than as a single constant.
+var permit2ChainIDOffsets = map[L2AllocsMode]int{
+	L2AllocsDelta:   6945,
+	L2AllocsEcotone: 6945,
+}
+
 type AllocsLoader func(mode L2AllocsMode) *ForgeAllocs
 
-// BuildL2Genesis will build the L2 genesis block.
-func BuildL2Genesis(config *DeployConfig, dump *ForgeAllocs, l1StartBlock *types.Block) (*core.Genesis, error) {
+// BuildL2Genesis will build the L2 genesis block, auto-resolving the forge
+// allocs to use for config's activated hardforks out of allocsDir.
+func BuildL2Genesis(config *DeployConfig, allocsDir string, l1StartBlock *types.Block) (*core.Genesis, error) {
+	mode, err := SelectAllocsMode(config)
+	if err != nil {
+		return nil, err
+	}
+	dump, err := LoadForgeAllocsForMode(allocsDir, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load forge allocs for mode %q: %w", mode, err)
+	}
+	return buildL2Genesis(config, dump, l1StartBlock, mode)
+}
+
+// BuildL2GenesisFromAllocs builds the L2 genesis block from an already
+// loaded ForgeAllocs dump, for callers that manage their own allocs loading
+// (e.g. test harnesses) rather than resolving a directory.
+func BuildL2GenesisFromAllocs(config *DeployConfig, dump *ForgeAllocs, l1StartBlock *types.Block) (*core.Genesis, error) {
+	mode, err := SelectAllocsMode(config)
+	if err != nil {
+		return nil, err
+	}
+	return buildL2Genesis(config, dump, l1StartBlock, mode)
+}
+
+func buildL2Genesis(config *DeployConfig, dump *ForgeAllocs, l1StartBlock *types.Block, mode L2AllocsMode) (*core.Genesis, error) {
 	genspec, err := NewL2Genesis(config, l1StartBlock)
 	if err != nil {
 		return nil, err
 	}
 	genspec.Alloc = dump.Accounts
-	// sanity check the permit2 immutable, to verify we using the allocs for the right chain.
-	chainID := [32]byte(genspec.Alloc[predeploys.Permit2Addr].Code[6945 : 6945+32])
+	if err := verifyPermit2ChainID(genspec, mode); err != nil {
+		return nil, err
+	}
+	return genspec, nil
+}
+
+// verifyPermit2ChainID sanity checks the permit2 immutable, to verify we're
+// using the allocs for the right chain. The offset it reads from is looked
+// up per-mode, since it can shift between compiler versions used to
+// regenerate allocs.
+func verifyPermit2ChainID(genspec *core.Genesis, mode L2AllocsMode) error {
+	offset, ok := permit2ChainIDOffsets[mode]
+	if !ok {
+		return fmt.Errorf("no known permit2 chain-ID offset for allocs mode %q", mode)
+	}
+	chainID := [32]byte(genspec.Alloc[predeploys.Permit2Addr].Code[offset : offset+32])
 	expected := uint256.MustFromBig(genspec.Config.ChainID).Bytes32()
 	if chainID != expected {
-		return nil, fmt.Errorf("allocs were generated for chain ID %x, but expected chain %x (%d)", chainID, expected, genspec.Config.ChainID)
+		return fmt.Errorf("allocs were generated for chain ID %x, but expected chain %x (%d)", chainID, expected, genspec.Config.ChainID)
 	}
-	return genspec, nil
+	return nil
 }
 
+// LoadForgeAllocs loads a forge allocs dump. It is a thin wrapper around
+// allocs.Load, kept for backward compatibility with existing callers.
 func LoadForgeAllocs(allocsPath string) (*ForgeAllocs, error) {
-	path := filepath.Join(allocsPath)
-	f, err := os.OpenFile(path, os.O_RDONLY, 0644)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open forge allocs %q: %w", path, err)
-	}
-	defer f.Close()
-	var out ForgeAllocs
-	if err := json.NewDecoder(f).Decode(&out); err != nil {
-		return nil, fmt.Errorf("failed to json-decode forge allocs %q: %w", path, err)
+	return allocs.Load(allocsPath)
+}
+
+// LoadForgeAllocsForMode loads the forge allocs file for mode out of
+// allocsDir (allocs-l2{suffix}.json).
+func LoadForgeAllocsForMode(allocsDir string, mode L2AllocsMode) (*ForgeAllocs, error) {
+	for _, entry := range allocsModeRegistry {
+		if entry.mode == mode {
+			return LoadForgeAllocs(filepath.Join(allocsDir, fmt.Sprintf("allocs-l2%s.json", entry.suffix)))
+		}
 	}
-	return &out, nil
+	return nil, fmt.Errorf("unregistered L2AllocsMode %q", mode)
 }
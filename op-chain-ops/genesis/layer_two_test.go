@@ -1,7 +1,6 @@
 package genesis_test
 
 import (
-	"context"
 	"encoding/json"
 	"flag"
 	"math/big"
@@ -11,17 +10,15 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis/genesistest"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
-	"github.com/ethereum/go-ethereum/eth/ethconfig"
 )
 
 var writeFile bool
@@ -30,70 +27,25 @@ func init() {
 	flag.BoolVar(&writeFile, "write-file", false, "write the genesis file to disk")
 }
 
-var testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
-
 // Tests the BuildL2MainnetGenesis factory with the provided config.
 func testBuildL2Genesis(t *testing.T, allocs *genesis.ForgeAllocs, config *genesis.DeployConfig) *core.Genesis {
-	backend := backends.NewSimulatedBackend( // nolint:staticcheck
-		core.GenesisAlloc{
-			crypto.PubkeyToAddress(testKey.PublicKey): {Balance: big.NewInt(10000000000000000)},
-		},
-		15000000,
-	)
-	block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	harness, err := genesistest.NewL2GenesisHarness(config, allocs)
 	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, harness.Close()) })
 
-	gen, err := genesis.BuildL2Genesis(config, allocs, block)
-	require.Nil(t, err)
-	require.NotNil(t, gen)
+	gen := harness.Genesis
+	// The harness funds TestKey on top of the built genesis; drop it so the
+	// allocation checks and counts see only what the genesis builder produced.
+	delete(gen.Alloc, crypto.PubkeyToAddress(genesistest.TestKey.PublicKey))
 
 	proxyBytecode, err := bindings.GetDeployedBytecode("Proxy")
 	require.NoError(t, err)
 
-	// for simulation we need a regular EVM, not with system-deposit information.
-	chainConfig := params.ChainConfig{
-		ChainID:             big.NewInt(1337),
-		HomesteadBlock:      big.NewInt(0),
-		DAOForkBlock:        nil,
-		DAOForkSupport:      false,
-		EIP150Block:         big.NewInt(0),
-		EIP155Block:         big.NewInt(0),
-		EIP158Block:         big.NewInt(0),
-		ByzantiumBlock:      big.NewInt(0),
-		ConstantinopleBlock: big.NewInt(0),
-		PetersburgBlock:     big.NewInt(0),
-		IstanbulBlock:       big.NewInt(0),
-		MuirGlacierBlock:    big.NewInt(0),
-		BerlinBlock:         big.NewInt(0),
-		LondonBlock:         big.NewInt(0),
-		ArrowGlacierBlock:   big.NewInt(0),
-		GrayGlacierBlock:    big.NewInt(0),
-		// Activated proof of stake. We manually build/commit blocks in the simulator anyway,
-		// and the timestamp verification of PoS is not against the wallclock,
-		// preventing blocks from getting stuck temporarily in the future-blocks queue, decreasing setup time a lot.
-		MergeNetsplitBlock:            big.NewInt(0),
-		TerminalTotalDifficulty:       big.NewInt(0),
-		TerminalTotalDifficultyPassed: true,
-		ShanghaiTime:                  new(uint64),
-	}
-
-	// Apply the genesis to the backend
-	cfg := ethconfig.Defaults
-	cfg.Preimages = true
-	cfg.Genesis = &core.Genesis{
-		Config:     &chainConfig,
-		Timestamp:  1234567,
-		Difficulty: big.NewInt(0),
-		Alloc:      gen.Alloc,
-		GasLimit:   30_000_000,
-	}
-	backend = backends.NewSimulatedBackendFromConfig(cfg)
-
 	for name, predeploy := range predeploys.Predeploys {
 		addr := predeploy.Address
 
 		if addr == predeploys.L1BlockAddr {
-			testL1Block(t, backend)
+			testL1Block(t, harness.Client)
 		}
 
 		account, ok := gen.Alloc[addr]
@@ -198,3 +150,31 @@ func TestCheckL2Genesis(t *testing.T) {
 
 	require.Equal(t, expected, len(gen.Alloc))
 }
+
+// TestVerifyL2Genesis drives the generated genesis forward with an
+// in-process CL-mock loop and checks that the L1Block predeploy tracks the
+// L1 origin fed into each produced block, rather than only sanity-checking
+// the allocation at block 0.
+func TestVerifyL2Genesis(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../../packages/contracts-bedrock/deploy-config/devnetL1.json")
+	require.Nil(t, err)
+	allocs, err := genesis.LoadForgeAllocs("../../.devnet/allocs-l2.json")
+	require.Nil(t, err)
+	config.EnableGovernance = true
+	config.FundDevAccounts = false
+
+	gen := testBuildL2Genesis(t, allocs, config)
+
+	err = genesis.VerifyL2Genesis(gen, genesis.VerifyOpts{
+		BlocksToBuild: 4,
+		BlockTime:     2,
+		L1Origin: genesis.L1BlockValues{
+			Number:      1,
+			Time:        1234567,
+			BaseFee:     big.NewInt(7),
+			BlobBaseFee: big.NewInt(1),
+			BatcherHash: common.Hash{},
+		},
+	})
+	require.NoError(t, err, "genesis must be drivable forward under the active hardfork rules")
+}
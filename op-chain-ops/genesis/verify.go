@@ -0,0 +1,228 @@
+package genesis
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// L1BlockValues mirrors the fields the L1Block predeploy is updated with by
+// the L1-info deposit transaction at the start of every L2 block.
+type L1BlockValues struct {
+	Number            uint64
+	Time              uint64
+	BaseFee           *big.Int
+	BlobBaseFee       *big.Int
+	BatcherHash       common.Hash
+	BaseFeeScalar     uint32
+	BlobBaseFeeScalar uint32
+}
+
+// VerifyOpts configures VerifyL2Genesis. It controls how many blocks the
+// CL-mock driver produces on top of the genesis block, and how the L1
+// origin advances between them.
+type VerifyOpts struct {
+	// BlocksToBuild is the number of post-genesis blocks the driver produces.
+	BlocksToBuild uint64
+	// BlockTime is the timestamp delta between produced blocks, in seconds.
+	BlockTime uint64
+	// L1Origin seeds the L1Block values applied at the first produced block;
+	// Number and Time are advanced by one and BlockTime respectively on every
+	// subsequent block.
+	L1Origin L1BlockValues
+}
+
+// VerifyL2Genesis drives gen forward with an in-process CL-mock loop and
+// asserts the L1Block predeploy tracks the L1-info deposit tx fed into each
+// produced block.
+func VerifyL2Genesis(gen *core.Genesis, opts VerifyOpts) error {
+	backend := simulated.NewBackend(gen.Alloc, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = gen
+	})
+	defer backend.Close()
+
+	client := backend.Client()
+	mock := &clMock{rpc: client.Client()}
+
+	l1 := opts.L1Origin
+	for i := uint64(0); i < opts.BlocksToBuild; i++ {
+		head, err := client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch head before block %d: %w", i, err)
+		}
+
+		depositTx, err := l1InfoDepositTx(l1)
+		if err != nil {
+			return fmt.Errorf("failed to build L1 info deposit tx for block %d: %w", i, err)
+		}
+
+		beaconRoot := common.Hash{}
+		attrs := &engine.PayloadAttributes{
+			Timestamp:             head.Time + opts.BlockTime,
+			SuggestedFeeRecipient: predeploys.SequencerFeeVaultAddr,
+			Transactions:          []hexutil.Bytes{depositTx},
+			NoTxPool:              true,
+			// V3 rejects the call without these, regardless of whether the
+			// genesis config has actually activated Shanghai/Cancun.
+			Withdrawals: make(types.Withdrawals, 0),
+			BeaconRoot:  &beaconRoot,
+		}
+
+		payload, err := mock.buildAndInsert(head.Hash(), attrs)
+		if err != nil {
+			return fmt.Errorf("failed to build block %d: %w", i, err)
+		}
+
+		if err := verifyL1Block(client, payload.BlockHash, l1); err != nil {
+			return fmt.Errorf("block %d: L1Block invariant violated: %w", i, err)
+		}
+
+		l1.Number++
+		l1.Time += opts.BlockTime
+	}
+	return nil
+}
+
+// l1InfoDepositTx RLP-encodes the L1-info deposit transaction a sequencer
+// would place first in the block to update the L1Block predeploy with l1.
+func l1InfoDepositTx(l1 L1BlockValues) ([]byte, error) {
+	info := derive.L1BlockInfo{
+		Number:            l1.Number,
+		Time:              l1.Time,
+		BaseFee:           l1.BaseFee,
+		BlobBaseFee:       l1.BlobBaseFee,
+		BatcherAddr:       common.BytesToAddress(l1.BatcherHash[:]),
+		BaseFeeScalar:     l1.BaseFeeScalar,
+		BlobBaseFeeScalar: l1.BlobBaseFeeScalar,
+	}
+	data, err := info.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal L1 info: %w", err)
+	}
+
+	source := derive.L1InfoDepositSource{L1BlockHash: info.BlockHash, SeqNumber: info.SequenceNumber}
+	tx := types.NewTx(&types.DepositTx{
+		SourceHash:          source.SourceHash(),
+		From:                derive.L1InfoDepositerAddress,
+		To:                  &predeploys.L1BlockAddr,
+		Mint:                nil,
+		Value:               big.NewInt(0),
+		Gas:                 150_000_000,
+		IsSystemTransaction: true,
+		Data:                data,
+	})
+	return tx.MarshalBinary()
+}
+
+// clMock is a minimal CL-mock driver: it advances the in-process engine one
+// block at a time using the same engine_forkchoiceUpdatedV3 / engine_newPayloadV3
+// sequence a real consensus client would issue.
+type clMock struct {
+	rpc *rpc.Client
+}
+
+func (m *clMock) buildAndInsert(head common.Hash, attrs *engine.PayloadAttributes) (*engine.ExecutableData, error) {
+	ctx := context.Background()
+
+	var fcResp engine.ForkChoiceResponse
+	state := engine.ForkchoiceStateV1{HeadBlockHash: head, SafeBlockHash: head, FinalizedBlockHash: head}
+	if err := m.rpc.CallContext(ctx, &fcResp, "engine_forkchoiceUpdatedV3", state, attrs); err != nil {
+		return nil, fmt.Errorf("forkchoiceUpdatedV3 failed: %w", err)
+	}
+	if fcResp.PayloadID == nil {
+		return nil, fmt.Errorf("forkchoiceUpdatedV3 did not return a payload id")
+	}
+
+	var payload engine.ExecutionPayloadEnvelope
+	if err := m.rpc.CallContext(ctx, &payload, "engine_getPayloadV3", *fcResp.PayloadID); err != nil {
+		return nil, fmt.Errorf("getPayloadV3 failed: %w", err)
+	}
+
+	var newPayloadResp engine.PayloadStatusV1
+	if err := m.rpc.CallContext(ctx, &newPayloadResp, "engine_newPayloadV3",
+		payload.ExecutionPayload, []common.Hash{}, payload.ExecutionPayload.ParentBeaconBlockRoot); err != nil {
+		return nil, fmt.Errorf("newPayloadV3 failed: %w", err)
+	}
+	if newPayloadResp.Status != engine.VALID {
+		return nil, fmt.Errorf("newPayloadV3 returned status %s", newPayloadResp.Status)
+	}
+
+	finalState := engine.ForkchoiceStateV1{
+		HeadBlockHash:      payload.ExecutionPayload.BlockHash,
+		SafeBlockHash:      payload.ExecutionPayload.BlockHash,
+		FinalizedBlockHash: payload.ExecutionPayload.BlockHash,
+	}
+	if err := m.rpc.CallContext(ctx, &fcResp, "engine_forkchoiceUpdatedV3", finalState, nil); err != nil {
+		return nil, fmt.Errorf("final forkchoiceUpdatedV3 failed: %w", err)
+	}
+	return payload.ExecutionPayload, nil
+}
+
+// verifyL1Block re-queries the L1Block predeploy at the produced block and
+// asserts its state matches the values the deposit transaction fed in.
+func verifyL1Block(client simulated.Client, block common.Hash, expect L1BlockValues) error {
+	caller, err := bindings.NewL1BlockCaller(predeploys.L1BlockAddr, client)
+	if err != nil {
+		return fmt.Errorf("failed to bind L1Block: %w", err)
+	}
+	opts := &bind.CallOpts{BlockHash: block}
+
+	if number, err := caller.Number(opts); err != nil {
+		return err
+	} else if number != expect.Number {
+		return fmt.Errorf("L1 number: have %d, want %d", number, expect.Number)
+	}
+
+	if timestamp, err := caller.Timestamp(opts); err != nil {
+		return err
+	} else if timestamp != expect.Time {
+		return fmt.Errorf("L1 time: have %d, want %d", timestamp, expect.Time)
+	}
+
+	if hash, err := caller.BatcherHash(opts); err != nil {
+		return err
+	} else if common.Hash(hash) != expect.BatcherHash {
+		return fmt.Errorf("batcher hash: have %x, want %x", hash, expect.BatcherHash)
+	}
+
+	if basefee, err := caller.Basefee(opts); err != nil {
+		return err
+	} else if basefee.Cmp(expect.BaseFee) != 0 {
+		return fmt.Errorf("basefee: have %s, want %s", basefee, expect.BaseFee)
+	}
+
+	if blobBaseFee, err := caller.BlobBaseFee(opts); err != nil {
+		return err
+	} else if blobBaseFee.Cmp(expect.BlobBaseFee) != 0 {
+		return fmt.Errorf("blob basefee: have %s, want %s", blobBaseFee, expect.BlobBaseFee)
+	}
+
+	if baseFeeScalar, err := caller.BaseFeeScalar(opts); err != nil {
+		return err
+	} else if baseFeeScalar != expect.BaseFeeScalar {
+		return fmt.Errorf("base fee scalar: have %d, want %d", baseFeeScalar, expect.BaseFeeScalar)
+	}
+
+	if blobBaseFeeScalar, err := caller.BlobBaseFeeScalar(opts); err != nil {
+		return err
+	} else if blobBaseFeeScalar != expect.BlobBaseFeeScalar {
+		return fmt.Errorf("blob base fee scalar: have %d, want %d", blobBaseFeeScalar, expect.BlobBaseFeeScalar)
+	}
+
+	return nil
+}